@@ -0,0 +1,25 @@
+package commands
+
+import (
+	objectcmd "github.com/ipfs/go-ipfs/core/commands/object"
+
+	cmds "github.com/ipfs/go-ipfs/commands"
+	cmdkit "gx/ipfs/QmceUdzxkimdYsgtX733uNgzf1DLHyBKN6ehGSp85ayppM/go-ipfs-cmdkit"
+)
+
+// ObjectCmd groups the plumbing commands used to inspect and mutate
+// merkledag objects directly.
+var ObjectCmd = &cmds.Command{
+	Helptext: cmdkit.HelpText{
+		Tagline: "Interact with IPFS objects.",
+		ShortDescription: `
+'ipfs object' is a plumbing command used to manipulate DAG objects
+directly.
+`,
+	},
+	Subcommands: map[string]*cmds.Command{
+		"diff":  objectcmd.ObjectDiffCmd,
+		"new":   objectcmd.ObjectNewCmd,
+		"patch": objectcmd.ObjectPatchCmd,
+	},
+}
@@ -0,0 +1,198 @@
+package objectcmd
+
+import (
+	"bufio"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	cmds "github.com/ipfs/go-ipfs/commands"
+	e "github.com/ipfs/go-ipfs/core/commands/e"
+	coreiface "github.com/ipfs/go-ipfs/core/coreapi/interface"
+
+	cmdkit "gx/ipfs/QmceUdzxkimdYsgtX733uNgzf1DLHyBKN6ehGSp85ayppM/go-ipfs-cmdkit"
+)
+
+// ObjectApplyResult is the wire representation of a single
+// coreiface.ObjectResult, as streamed by `ipfs object patch apply`.
+type ObjectApplyResult struct {
+	Cid  string
+	Root bool   `json:",omitempty"`
+	Err  string `json:",omitempty"`
+}
+
+var patchApplyCmd = &cmds.Command{
+	Helptext: cmdkit.HelpText{
+		Tagline: "Apply a stream of patch operations to an object.",
+		ShortDescription: `
+'ipfs object patch apply <root>' reads a newline-delimited stream of JSON
+patch operations from stdin and applies them to root one at a time,
+printing the CID of the object after each op as soon as it's applied,
+and the final CID of the committed root once stdin is closed.
+
+Unlike 'ipfs object patch batch', which reads the whole changeset before
+applying anything, apply processes ops as they arrive - useful for
+long-lived scripted graph builders that want feedback per-op without
+paying for an exec per mutation.
+
+Supported ops are the same as for 'ipfs object patch batch':
+
+    {"op":"add-link","name":"foo","ref":"Qm...","create":true}
+    {"op":"rm-link","name":"foo"}
+    {"op":"append-data","data":"<base64>"}
+    {"op":"set-data","data":"<base64>"}
+`,
+	},
+	Arguments: []cmdkit.Argument{
+		cmdkit.StringArg("root", true, false, "The hash of the node to modify."),
+		cmdkit.FileArg("changes", true, false, "Newline-delimited JSON ops.").EnableStdin(),
+	},
+	Run: func(req cmds.Request, res cmds.Response) {
+		api, err := req.InvocContext().GetApi()
+		if err != nil {
+			res.SetError(err, cmdkit.ErrNormal)
+			return
+		}
+
+		resolver := api.Resolver()
+
+		root, err := resolveArg(req, api, resolver, req.Arguments()[0])
+		if err != nil {
+			res.SetError(err, cmdkit.ErrNormal)
+			return
+		}
+
+		changes, err := req.Files().NextFile()
+		if err != nil {
+			res.SetError(err, cmdkit.ErrNormal)
+			return
+		}
+
+		ops := make(chan coreiface.ObjectOp)
+
+		results, err := api.Object().Apply(req.Context(), root, ops)
+		if err != nil {
+			res.SetError(err, cmdkit.ErrNormal)
+			return
+		}
+
+		go feedOps(req, api, resolver, changes, ops)
+
+		res.SetOutput(toInterfaceChan(results))
+	},
+	Type: ObjectApplyResult{},
+	Marshalers: cmds.MarshalerMap{
+		cmds.Text: func(res cmds.Response) (io.Reader, error) {
+			outChan, ok := res.Output().(<-chan interface{})
+			if !ok {
+				return nil, e.TypeErr(outChan, res.Output())
+			}
+
+			pr, pw := io.Pipe()
+			go func() {
+				for v := range outChan {
+					r, ok := v.(*ObjectApplyResult)
+					if !ok {
+						pw.CloseWithError(e.TypeErr(r, v))
+						return
+					}
+
+					if r.Err != "" {
+						pw.CloseWithError(fmt.Errorf("%s", r.Err))
+						return
+					}
+
+					fmt.Fprintln(pw, r.Cid)
+				}
+				pw.Close()
+			}()
+
+			return pr, nil
+		},
+	},
+}
+
+// feedOps decodes the newline-delimited JSON changeset from changes and
+// feeds it onto ops in order, closing ops once the stream is exhausted. A
+// line that fails to unmarshal or decode is reported as an ObjectOp with Err
+// set, rather than dropped - Apply surfaces it on the result stream and
+// aborts without committing, instead of silently ending the stream early
+// and reporting a truncated changeset as a success.
+func feedOps(req cmds.Request, api coreiface.CoreAPI, resolver coreiface.ResolverAPI, changes io.Reader, ops chan<- coreiface.ObjectOp) {
+	defer close(ops)
+
+	scanner := bufio.NewScanner(changes)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		var raw batchOp
+		if err := json.Unmarshal([]byte(line), &raw); err != nil {
+			ops <- coreiface.ObjectOp{Err: fmt.Errorf("invalid op %q: %s", line, err)}
+			return
+		}
+
+		op, err := decodeOp(req, api, resolver, raw)
+		if err != nil {
+			ops <- coreiface.ObjectOp{Err: err}
+			return
+		}
+
+		ops <- op
+	}
+
+	if err := scanner.Err(); err != nil {
+		ops <- coreiface.ObjectOp{Err: err}
+	}
+}
+
+// decodeOp converts the JSON wire form shared with `object patch batch`
+// into a coreiface.ObjectOp, resolving "ref" against resolver.
+func decodeOp(req cmds.Request, api coreiface.CoreAPI, resolver coreiface.ResolverAPI, raw batchOp) (coreiface.ObjectOp, error) {
+	op := coreiface.ObjectOp{
+		Op:     coreiface.ObjectOpType(raw.Op),
+		Name:   raw.Name,
+		Create: raw.Create,
+	}
+
+	if raw.Ref != "" {
+		ref, err := resolveArg(req, api, resolver, raw.Ref)
+		if err != nil {
+			return coreiface.ObjectOp{}, err
+		}
+		op.Ref = ref
+	}
+
+	if raw.Data != "" {
+		data, err := base64.StdEncoding.DecodeString(raw.Data)
+		if err != nil {
+			return coreiface.ObjectOp{}, err
+		}
+		op.Data = data
+	}
+
+	return op, nil
+}
+
+// toInterfaceChan adapts a <-chan coreiface.ObjectResult to the
+// <-chan interface{} shape the streaming command Marshaler above expects.
+func toInterfaceChan(results <-chan coreiface.ObjectResult) <-chan interface{} {
+	out := make(chan interface{})
+
+	go func() {
+		defer close(out)
+		for r := range results {
+			if r.Err != nil {
+				out <- &ObjectApplyResult{Err: r.Err.Error()}
+				return
+			}
+			out <- &ObjectApplyResult{Cid: r.Cid.String(), Root: r.Root}
+		}
+	}()
+
+	return out
+}
@@ -0,0 +1,150 @@
+package objectcmd
+
+import (
+	"bufio"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	cmds "github.com/ipfs/go-ipfs/commands"
+	coreiface "github.com/ipfs/go-ipfs/core/coreapi/interface"
+
+	cmdkit "gx/ipfs/QmceUdzxkimdYsgtX733uNgzf1DLHyBKN6ehGSp85ayppM/go-ipfs-cmdkit"
+)
+
+// batchOp is a single line of the newline-delimited JSON changeset accepted
+// by `ipfs object patch batch`.
+type batchOp struct {
+	Op     string `json:"op"`
+	Name   string `json:"name,omitempty"`
+	Ref    string `json:"ref,omitempty"`
+	Create bool   `json:"create,omitempty"`
+	Data   string `json:"data,omitempty"`
+}
+
+var patchBatchCmd = &cmds.Command{
+	Helptext: cmdkit.HelpText{
+		Tagline: "Apply a batch of patch operations to an object atomically.",
+		ShortDescription: `
+'ipfs object patch batch <root>' reads a newline-delimited list of JSON
+patch operations from stdin and applies them to root as a single
+in-memory edit, only writing the final result to the blockstore once all
+operations have succeeded.
+
+Supported ops:
+
+    {"op":"add-link","name":"foo","ref":"Qm...","create":true}
+    {"op":"rm-link","name":"foo"}
+    {"op":"append-data","data":"<base64>"}
+    {"op":"set-data","data":"<base64>"}
+
+Example:
+
+	$ ipfs object patch batch $ROOT <<EOF
+	{"op":"add-link","name":"a","ref":"Qm..."}
+	{"op":"add-link","name":"b","ref":"Qm..."}
+	EOF
+`,
+	},
+	Arguments: []cmdkit.Argument{
+		cmdkit.StringArg("root", true, false, "The hash of the node to modify."),
+		cmdkit.FileArg("changes", true, false, "Newline-delimited JSON ops.").EnableStdin(),
+	},
+	Run: func(req cmds.Request, res cmds.Response) {
+		api, err := req.InvocContext().GetApi()
+		if err != nil {
+			res.SetError(err, cmdkit.ErrNormal)
+			return
+		}
+
+		resolver := api.Resolver()
+
+		root, err := resolveArg(req, api, resolver, req.Arguments()[0])
+		if err != nil {
+			res.SetError(err, cmdkit.ErrNormal)
+			return
+		}
+
+		batch, err := api.Object().Batch(req.Context(), root)
+		if err != nil {
+			res.SetError(err, cmdkit.ErrNormal)
+			return
+		}
+
+		changes, err := req.Files().NextFile()
+		if err != nil {
+			res.SetError(err, cmdkit.ErrNormal)
+			return
+		}
+
+		scanner := bufio.NewScanner(changes)
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			if line == "" {
+				continue
+			}
+
+			var op batchOp
+			if err := json.Unmarshal([]byte(line), &op); err != nil {
+				res.SetError(fmt.Errorf("invalid op %q: %s", line, err), cmdkit.ErrNormal)
+				return
+			}
+
+			if err := applyBatchOp(req, api, resolver, batch, op); err != nil {
+				res.SetError(err, cmdkit.ErrNormal)
+				return
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			res.SetError(err, cmdkit.ErrNormal)
+			return
+		}
+
+		p, err := batch.Commit()
+		if err != nil {
+			res.SetError(err, cmdkit.ErrNormal)
+			return
+		}
+
+		res.SetOutput(&Object{Hash: p.Cid().String()})
+	},
+	Type: Object{},
+	Marshalers: cmds.MarshalerMap{
+		cmds.Text: objectMarshaler,
+	},
+}
+
+func applyBatchOp(req cmds.Request, api coreiface.CoreAPI, resolver coreiface.ResolverAPI, batch coreiface.ObjectBatch, op batchOp) error {
+	switch op.Op {
+	case "add-link":
+		child, err := resolveArg(req, api, resolver, op.Ref)
+		if err != nil {
+			return err
+		}
+
+		return batch.AddLink(op.Name, child, api.Object().WithCreate(op.Create))
+
+	case "rm-link":
+		return batch.RmLink(op.Name)
+
+	case "append-data":
+		data, err := base64.StdEncoding.DecodeString(op.Data)
+		if err != nil {
+			return err
+		}
+
+		return batch.AppendData(strings.NewReader(string(data)))
+
+	case "set-data":
+		data, err := base64.StdEncoding.DecodeString(op.Data)
+		if err != nil {
+			return err
+		}
+
+		return batch.SetData(strings.NewReader(string(data)))
+
+	default:
+		return fmt.Errorf("unknown op %q", op.Op)
+	}
+}
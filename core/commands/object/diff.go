@@ -0,0 +1,136 @@
+package objectcmd
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+
+	cmds "github.com/ipfs/go-ipfs/commands"
+	e "github.com/ipfs/go-ipfs/core/commands/e"
+	coreiface "github.com/ipfs/go-ipfs/core/coreapi/interface"
+
+	cmdkit "gx/ipfs/QmceUdzxkimdYsgtX733uNgzf1DLHyBKN6ehGSp85ayppM/go-ipfs-cmdkit"
+)
+
+// ObjectDiffParams carries the result of a single Object().Diff() call over
+// the wire.
+type ObjectDiffParams struct {
+	Changes []ObjectDiffChange
+}
+
+// ObjectDiffChange is the wire representation of a coreiface.ObjectChange.
+type ObjectDiffChange struct {
+	Type   string
+	Path   string
+	Before string `json:",omitempty"`
+	After  string `json:",omitempty"`
+}
+
+var ObjectDiffCmd = &cmds.Command{
+	Helptext: cmdkit.HelpText{
+		Tagline: "Display the diff between two IPFS objects.",
+		ShortDescription: `
+'ipfs object diff' is a plumbing command used to show the differences
+between two IPFS objects, matching links by name and recursing into
+matching, differing links.
+`,
+	},
+	Arguments: []cmdkit.Argument{
+		cmdkit.StringArg("object_a", true, false, "Object to diff against."),
+		cmdkit.StringArg("object_b", true, false, "Object to diff."),
+	},
+	Options: []cmdkit.Option{
+		cmdkit.BoolOption("verbose", "v", "Print extra information."),
+	},
+	Run: func(req cmds.Request, res cmds.Response) {
+		api, err := req.InvocContext().GetApi()
+		if err != nil {
+			res.SetError(err, cmdkit.ErrNormal)
+			return
+		}
+
+		resolver := api.Resolver()
+
+		before, err := resolveArg(req, api, resolver, req.Arguments()[0])
+		if err != nil {
+			res.SetError(err, cmdkit.ErrNormal)
+			return
+		}
+
+		after, err := resolveArg(req, api, resolver, req.Arguments()[1])
+		if err != nil {
+			res.SetError(err, cmdkit.ErrNormal)
+			return
+		}
+
+		changes, err := api.Object().Diff(req.Context(), before, after)
+		if err != nil {
+			res.SetError(err, cmdkit.ErrNormal)
+			return
+		}
+
+		out := &ObjectDiffParams{Changes: make([]ObjectDiffChange, len(changes))}
+		for i, c := range changes {
+			dc := ObjectDiffChange{Path: c.Path}
+
+			switch c.Type {
+			case coreiface.DiffAdd:
+				dc.Type = "Added"
+			case coreiface.DiffRemove:
+				dc.Type = "Removed"
+			case coreiface.DiffMod:
+				dc.Type = "Changed"
+			}
+
+			if c.Before != nil {
+				dc.Before = c.Before.Cid().String()
+			}
+			if c.After != nil {
+				dc.After = c.After.Cid().String()
+			}
+
+			out.Changes[i] = dc
+		}
+
+		res.SetOutput(out)
+	},
+	Type: ObjectDiffParams{},
+	Marshalers: cmds.MarshalerMap{
+		cmds.Text: func(res cmds.Response) (io.Reader, error) {
+			v, err := unwrapOutput(res.Output())
+			if err != nil {
+				return nil, err
+			}
+
+			out, ok := v.(*ObjectDiffParams)
+			if !ok {
+				return nil, e.TypeErr(out, v)
+			}
+
+			verbose, _, err := res.Request().Option("verbose").Bool()
+			if err != nil {
+				return nil, err
+			}
+
+			buf := new(bytes.Buffer)
+			for _, c := range out.Changes {
+				if !verbose {
+					fmt.Fprintf(buf, "%s %s\n", strings.ToUpper(c.Type[:1]), c.Path)
+					continue
+				}
+
+				switch c.Type {
+				case "Added":
+					fmt.Fprintf(buf, "Added link %q pointing to %s\n", c.Path, c.After)
+				case "Removed":
+					fmt.Fprintf(buf, "Removed link %q (was %s)\n", c.Path, c.Before)
+				case "Changed":
+					fmt.Fprintf(buf, "Changed %q from %s to %s\n", c.Path, c.Before, c.After)
+				}
+			}
+
+			return buf, nil
+		},
+	},
+}
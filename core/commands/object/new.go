@@ -0,0 +1,48 @@
+package objectcmd
+
+import (
+	cmds "github.com/ipfs/go-ipfs/commands"
+	caopts "github.com/ipfs/go-ipfs/core/coreapi/interface/options"
+
+	cmdkit "gx/ipfs/QmceUdzxkimdYsgtX733uNgzf1DLHyBKN6ehGSp85ayppM/go-ipfs-cmdkit"
+)
+
+var ObjectNewCmd = &cmds.Command{
+	Helptext: cmdkit.HelpText{
+		Tagline: "Create a new object from an ipfs template.",
+		ShortDescription: `
+'ipfs object new' creates a new object and prints its key.
+
+By default it creates an empty merkledag node, but passing an optional
+template (e.g. "unixfs-dir") will create a special object instead, such as
+an empty UnixFS directory for use with 'ipfs object patch add-link'.
+`,
+	},
+	Arguments: []cmdkit.Argument{
+		cmdkit.StringArg("template", false, false, "Template to use. Optional. One of: empty, unixfs-dir."),
+	},
+	Run: func(req cmds.Request, res cmds.Response) {
+		api, err := req.InvocContext().GetApi()
+		if err != nil {
+			res.SetError(err, cmdkit.ErrNormal)
+			return
+		}
+
+		var opts []caopts.ObjectNewOption
+		if len(req.Arguments()) > 0 {
+			opts = append(opts, caopts.Object.Type(req.Arguments()[0]))
+		}
+
+		p, err := api.Object().New(req.Context(), opts...)
+		if err != nil {
+			res.SetError(err, cmdkit.ErrNormal)
+			return
+		}
+
+		res.SetOutput(&Object{Hash: p.Cid().String()})
+	},
+	Type: Object{},
+	Marshalers: cmds.MarshalerMap{
+		cmds.Text: objectMarshaler,
+	},
+}
@@ -0,0 +1,29 @@
+package objectcmd
+
+import (
+	"fmt"
+
+	e "github.com/ipfs/go-ipfs/core/commands/e"
+)
+
+// Object is the result type returned by the object patch/new/put commands:
+// the hash of the (possibly newly created) object.
+type Object struct {
+	Hash string
+}
+
+// unwrapOutput pulls the first (and only) value out of a streaming command
+// response, as produced by res.Output() for non-channel commands.
+func unwrapOutput(i interface{}) (interface{}, error) {
+	ch, ok := i.(<-chan interface{})
+	if !ok {
+		return nil, e.TypeErr(ch, i)
+	}
+
+	out, ok := <-ch
+	if !ok {
+		return nil, fmt.Errorf("no command response to unwrap")
+	}
+
+	return out, nil
+}
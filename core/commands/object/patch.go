@@ -6,10 +6,26 @@ import (
 
 	cmds "github.com/ipfs/go-ipfs/commands"
 	e "github.com/ipfs/go-ipfs/core/commands/e"
+	coreiface "github.com/ipfs/go-ipfs/core/coreapi/interface"
 
 	cmdkit "gx/ipfs/QmceUdzxkimdYsgtX733uNgzf1DLHyBKN6ehGSp85ayppM/go-ipfs-cmdkit"
 )
 
+// resolveArg parses arg without resolving it, then resolves it through
+// resolver, replacing the api.ParsePath(..., api.WithResolve(true)) calls
+// that used to be repeated at every patch command call site. Callers that
+// resolve more than one argument for a single request (e.g. root and child
+// in add-link) should share a single resolver so repeat lookups of the same
+// path hit its cache.
+func resolveArg(req cmds.Request, api coreiface.CoreAPI, resolver coreiface.ResolverAPI, arg string) (coreiface.Path, error) {
+	p, err := api.ParsePath(req.Context(), arg, api.WithResolve(false))
+	if err != nil {
+		return nil, err
+	}
+
+	return resolver.Resolve(req.Context(), p)
+}
+
 var ObjectPatchCmd = &cmds.Command{
 	Helptext: cmdkit.HelpText{
 		Tagline: "Create a new merkledag object based on an existing one.",
@@ -25,6 +41,8 @@ result. This is the Merkle-DAG version of modifying an object.
 		"add-link":    patchAddLinkCmd,
 		"rm-link":     patchRmLinkCmd,
 		"set-data":    patchSetDataCmd,
+		"batch":       patchBatchCmd,
+		"apply":       patchApplyCmd,
 	},
 }
 
@@ -68,7 +86,7 @@ the limit will not be respected by the network.
 			return
 		}
 
-		root, err := api.ParsePath(req.Context(), req.StringArguments()[0], api.WithResolve(true))
+		root, err := resolveArg(req, api, api.Resolver(), req.StringArguments()[0])
 		if err != nil {
 			res.SetError(err, cmdkit.ErrNormal)
 			return
@@ -115,7 +133,7 @@ Example:
 			res.SetError(err, cmdkit.ErrNormal)
 			return
 		}
-		root, err := api.ParsePath(req.Context(), req.StringArguments()[0], api.WithResolve(true))
+		root, err := resolveArg(req, api, api.Resolver(), req.StringArguments()[0])
 		if err != nil {
 			res.SetError(err, cmdkit.ErrNormal)
 			return
@@ -159,7 +177,7 @@ Removes a link by the given name from root.
 			return
 		}
 
-		root, err := api.ParsePath(req.Context(), req.Arguments()[0], api.WithResolve(true))
+		root, err := resolveArg(req, api, api.Resolver(), req.Arguments()[0])
 		if err != nil {
 			res.SetError(err, cmdkit.ErrNormal)
 			return
@@ -211,7 +229,9 @@ to a file containing 'bar', and returns the hash of the new object.
 			return
 		}
 
-		root, err := api.ParsePath(req.Context(), req.Arguments()[0], api.WithResolve(true))
+		resolver := api.Resolver()
+
+		root, err := resolveArg(req, api, resolver, req.Arguments()[0])
 		if err != nil {
 			res.SetError(err, cmdkit.ErrNormal)
 			return
@@ -219,7 +239,7 @@ to a file containing 'bar', and returns the hash of the new object.
 
 		name := req.Arguments()[1]
 
-		child, err := api.ParsePath(req.Context(), req.Arguments()[2], api.WithResolve(true))
+		child, err := resolveArg(req, api, resolver, req.Arguments()[2])
 		if err != nil {
 			res.SetError(err, cmdkit.ErrNormal)
 			return
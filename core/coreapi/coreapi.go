@@ -8,7 +8,7 @@ import (
 
 type CoreAPI struct {
 	node *core.IpfsNode
-	*caopts.ApiOptions
+	*caopts.ApiSettings
 }
 
 // NewCoreAPI creates new instance of IPFS CoreAPI backed by go-ipfs Node.
@@ -49,3 +49,8 @@ func (api *CoreAPI) Object() coreiface.ObjectAPI {
 func (api *CoreAPI) Pin() coreiface.PinAPI {
 	return &PinAPI{api, nil}
 }
+
+// Resolver returns the ResolverAPI interface backed by the go-ipfs node
+func (api *CoreAPI) Resolver() coreiface.ResolverAPI {
+	return newResolverAPI(api)
+}
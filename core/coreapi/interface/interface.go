@@ -0,0 +1,84 @@
+package iface
+
+import (
+	"context"
+	"io"
+
+	options "github.com/ipfs/go-ipfs/core/coreapi/interface/options"
+
+	ipld "gx/ipfs/QmR7TcHkR9nxkUorfi8XMTAMLUK7GiP64TWWBzY3aacc1o/go-ipld-format"
+)
+
+// CoreAPI defines an unified interface to IPFS for Go programs.
+type CoreAPI interface {
+	// Unixfs returns an implementation of the Unixfs API.
+	Unixfs() UnixfsAPI
+
+	// Block returns an implementation of the Block API.
+	Block() BlockAPI
+
+	// Dag returns an implementation of the Dag API.
+	Dag() DagAPI
+
+	// Name returns an implementation of the Name API.
+	Name() NameAPI
+
+	// Key returns an implementation of the Key API.
+	Key() KeyAPI
+
+	// Object returns an implementation of the Object API.
+	Object() ObjectAPI
+
+	// Pin returns an implementation of the Pin API.
+	Pin() PinAPI
+
+	// Resolver returns an implementation of the Resolver API.
+	Resolver() ResolverAPI
+
+	// ParsePath parses a path string into a Path, optionally resolving it
+	// against /ipfs, /ipld or /ipns.
+	ParsePath(ctx context.Context, p string, opts ...options.ApiOption) (Path, error)
+
+	// WithResolve toggles whether ParsePath resolves the path before
+	// returning it.
+	WithResolve(resolve bool) options.ApiOption
+}
+
+// UnixfsAPI is the interface to interact with IPFS Unixfs objects.
+type UnixfsAPI interface {
+	Add(context.Context, io.Reader) (Path, error)
+	Cat(context.Context, Path) (io.ReadCloser, error)
+	Ls(context.Context, Path) ([]*ipld.Link, error)
+}
+
+// BlockAPI is the interface to interact with raw IPFS blocks.
+type BlockAPI interface {
+	Put(context.Context, io.Reader) (Path, error)
+	Get(context.Context, Path) (io.Reader, error)
+	Rm(context.Context, Path) error
+	Stat(context.Context, Path) (Path, error)
+}
+
+// DagAPI is the interface to interact with IPLD DAG objects.
+type DagAPI interface {
+	Put(context.Context, io.Reader) (Path, error)
+	Get(context.Context, Path) (ipld.Node, error)
+}
+
+// NameAPI is the interface to IPNS.
+type NameAPI interface {
+	Publish(ctx context.Context, p Path) (Path, error)
+	Resolve(ctx context.Context, name string) (Path, error)
+}
+
+// KeyAPI is the interface to IPFS keystore.
+type KeyAPI interface {
+	Generate(ctx context.Context, name string) (Path, error)
+	List(ctx context.Context) ([]Path, error)
+}
+
+// PinAPI is the interface to pinning.
+type PinAPI interface {
+	Add(ctx context.Context, p Path) error
+	Rm(ctx context.Context, p Path) error
+}
@@ -0,0 +1,172 @@
+package iface
+
+import (
+	"context"
+	"io"
+
+	options "github.com/ipfs/go-ipfs/core/coreapi/interface/options"
+
+	ipld "gx/ipfs/QmR7TcHkR9nxkUorfi8XMTAMLUK7GiP64TWWBzY3aacc1o/go-ipld-format"
+	cid "gx/ipfs/QmcTcsTvfaeEBRFo1TkFgT8sRmgi1n1LTZpecfGUuqskzP/go-cid"
+)
+
+// ObjectStat provides a statistical summary of an IPFS object.
+type ObjectStat struct {
+	Cid            cid.Cid
+	NumLinks       int
+	BlockSize      int
+	LinksSize      int
+	DataSize       int
+	CumulativeSize int
+}
+
+// ObjectAddLinkSettings is the set of options accepted by ObjectAPI.AddLink.
+type ObjectAddLinkSettings struct {
+	Create bool
+}
+
+// ObjectAddLinkOption mutates ObjectAddLinkSettings.
+type ObjectAddLinkOption func(*ObjectAddLinkSettings)
+
+// ChangeType denotes the kind of change a single ObjectChange describes.
+type ChangeType int
+
+const (
+	// DiffAdd is emitted when a link only exists in the "after" object.
+	DiffAdd ChangeType = iota
+	// DiffRemove is emitted when a link only exists in the "before" object.
+	DiffRemove
+	// DiffMod is emitted when a link with the same name points at a
+	// different CID in "before" and "after".
+	DiffMod
+)
+
+// ObjectChange describes a single link-level change between two merkledag
+// objects, as produced by ObjectAPI.Diff.
+type ObjectChange struct {
+	Type   ChangeType
+	Path   string
+	Before Path
+	After  Path
+}
+
+// ObjectAPI specifies the interface to MerkleDAG and UnixFS objects.
+type ObjectAPI interface {
+	// New creates a new object from a template. By default, the template
+	// is an empty ProtoNode; pass options.Object.Type("unixfs-dir") to
+	// get an empty UnixFS directory instead.
+	New(context.Context, ...options.ObjectNewOption) (Path, error)
+
+	// Put imports the data into merkledag.
+	Put(context.Context, io.Reader) (Path, error)
+
+	// Get returns the node for the path.
+	Get(context.Context, Path) (ipld.Node, error)
+
+	// Data returns the data of the node.
+	Data(context.Context, Path) (io.Reader, error)
+
+	// Links returns the links the object has.
+	Links(context.Context, Path) ([]*ipld.Link, error)
+
+	// Stat returns information about the dag object.
+	Stat(context.Context, Path) (*ObjectStat, error)
+
+	// AddLink adds a link under the given name to the child object, and
+	// returns the path of the result.
+	AddLink(ctx context.Context, base Path, name string, child Path, opts ...ObjectAddLinkOption) (Path, error)
+
+	// RmLink removes a link from the object, and returns the path of the
+	// result.
+	RmLink(ctx context.Context, base Path, link string) (Path, error)
+
+	// AppendData appends data to the object, and returns the path of the
+	// result.
+	AppendData(context.Context, Path, io.Reader) (Path, error)
+
+	// SetData sets the data of the object, and returns the path of the
+	// result.
+	SetData(context.Context, Path, io.Reader) (Path, error)
+
+	// WithCreate builds an ObjectAddLinkOption that toggles whether
+	// AddLink should create intermediary nodes along the given path.
+	WithCreate(create bool) ObjectAddLinkOption
+
+	// Diff returns a set of changes that transform the "before" object
+	// into the "after" object. It is the merkledag equivalent of `diff -r`.
+	Diff(ctx context.Context, before Path, after Path) ([]ObjectChange, error)
+
+	// Batch returns a builder that accumulates a series of patch
+	// operations against root in memory, writing only the final
+	// resulting node(s) to the blockstore on Commit.
+	Batch(ctx context.Context, root Path) (ObjectBatch, error)
+
+	// Apply consumes ops in order against root, reusing the same
+	// in-memory node between steps, and reports a result after each op
+	// plus a final result for the committed root once ops is closed.
+	// This is the streaming counterpart to Batch, for callers that want
+	// to pipe a long-lived changeset through a single call instead of
+	// building the whole batch up front.
+	Apply(ctx context.Context, root Path, ops <-chan ObjectOp) (<-chan ObjectResult, error)
+}
+
+// ObjectOpType identifies the kind of mutation an ObjectOp describes.
+type ObjectOpType string
+
+const (
+	// OpAddLink adds a link; Name and Ref (and optionally Create) apply.
+	OpAddLink ObjectOpType = "add-link"
+	// OpRmLink removes a link; Name applies.
+	OpRmLink ObjectOpType = "rm-link"
+	// OpAppendData appends to the data segment; Data applies.
+	OpAppendData ObjectOpType = "append-data"
+	// OpSetData replaces the data segment; Data applies.
+	OpSetData ObjectOpType = "set-data"
+)
+
+// ObjectOp is a single step of an Apply changeset.
+type ObjectOp struct {
+	Op     ObjectOpType
+	Name   string
+	Ref    Path
+	Create bool
+	Data   []byte
+
+	// Err lets a producer that failed to decode or resolve an op (e.g. a
+	// malformed changeset line) report the failure through the ops channel
+	// itself rather than closing it silently. Apply reports Err on the
+	// result stream and stops without committing.
+	Err error
+}
+
+// ObjectResult is emitted on Apply's result channel: one per consumed
+// ObjectOp, reporting the CID of the node after that op was applied, and
+// one final result (with Root set) once ops is closed and the
+// accumulated node has been committed to the blockstore.
+type ObjectResult struct {
+	Cid  cid.Cid
+	Root bool
+	Err  error
+}
+
+// ObjectBatch accumulates patch operations against a single root object,
+// applying them to an in-memory node and only persisting the result when
+// Commit is called.
+type ObjectBatch interface {
+	// AppendData appends to the data segment of the in-progress node.
+	AppendData(io.Reader) error
+
+	// SetData replaces the data segment of the in-progress node.
+	SetData(io.Reader) error
+
+	// AddLink adds a link under name pointing at child.
+	AddLink(name string, child Path, opts ...ObjectAddLinkOption) error
+
+	// RmLink removes the link under name.
+	RmLink(name string) error
+
+	// Commit writes the accumulated node (and, transitively, any new
+	// intermediary nodes created along the way) to the blockstore and
+	// returns its path.
+	Commit() (Path, error)
+}
@@ -0,0 +1,41 @@
+package options
+
+// ObjectNewSettings is the set of options accepted by ObjectAPI.New.
+type ObjectNewSettings struct {
+	Type string
+}
+
+// ObjectNewOption mutates ObjectNewSettings.
+type ObjectNewOption func(*ObjectNewSettings) error
+
+// ObjectNewOptions applies a list of ObjectNewOption on top of the default
+// ObjectNewSettings (an empty ProtoNode).
+func ObjectNewOptions(opts []ObjectNewOption) (*ObjectNewSettings, error) {
+	settings := &ObjectNewSettings{
+		Type: "empty",
+	}
+
+	for _, opt := range opts {
+		if err := opt(settings); err != nil {
+			return nil, err
+		}
+	}
+
+	return settings, nil
+}
+
+type objectOpts struct{}
+
+// Object groups the functional options accepted by the Object API, e.g.
+// Object().New(ctx, options.Object.Type("unixfs-dir")).
+var Object objectOpts
+
+// Type selects the template ObjectAPI.New constructs the node from: "empty"
+// (the default, a bare ProtoNode) or "unixfs-dir" (an empty UnixFS
+// directory).
+func (objectOpts) Type(t string) ObjectNewOption {
+	return func(settings *ObjectNewSettings) error {
+		settings.Type = t
+		return nil
+	}
+}
@@ -0,0 +1,30 @@
+// Package options houses the functional options consumed by the CoreAPI
+// implementations under core/coreapi.
+package options
+
+// ApiSettings gathers the options accepted by CoreAPI-wide calls such as
+// ParsePath.
+type ApiSettings struct {
+	Offline bool
+	Resolve bool
+}
+
+// ApiOption mutates ApiSettings. Used as the variadic option type accepted
+// by CoreAPI.ParsePath and friends.
+type ApiOption func(*ApiSettings) error
+
+// ApiOptions applies a list of ApiOption on top of the default ApiSettings.
+func ApiOptions(opts ...ApiOption) (*ApiSettings, error) {
+	settings := &ApiSettings{
+		Offline: false,
+		Resolve: true,
+	}
+
+	for _, opt := range opts {
+		if err := opt(settings); err != nil {
+			return nil, err
+		}
+	}
+
+	return settings, nil
+}
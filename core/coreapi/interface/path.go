@@ -0,0 +1,32 @@
+package iface
+
+import (
+	cid "gx/ipfs/QmcTcsTvfaeEBRFo1TkFgT8sRmgi1n1LTZpecfGUuqskzP/go-cid"
+)
+
+// Path is a generic wrapper for paths used in the API. A path can be resolved
+// to a CID using one of Resolve functions in the API.
+type Path interface {
+	// String returns the path as a string.
+	String() string
+
+	// Cid returns the CID of the node the path was resolved to, or
+	// cid.Undef if the path has not been resolved.
+	Cid() cid.Cid
+
+	// Root returns the CID of the object the path starts from, or
+	// cid.Undef if the path has not been resolved.
+	Root() cid.Cid
+
+	// Remainder returns the slash-delimited remainder of the path after
+	// the root object, or the empty string if this path points at the
+	// root object.
+	Remainder() string
+}
+
+// ResolvedPath is a Path which has been resolved to a specific CID,
+// remembering the root and any remaining path segments that were left
+// unresolved (e.g. inside non-unixfs data).
+type ResolvedPath interface {
+	Path
+}
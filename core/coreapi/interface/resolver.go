@@ -0,0 +1,26 @@
+package iface
+
+import (
+	"context"
+
+	ipld "gx/ipfs/QmR7TcHkR9nxkUorfi8XMTAMLUK7GiP64TWWBzY3aacc1o/go-ipld-format"
+	cid "gx/ipfs/QmcTcsTvfaeEBRFo1TkFgT8sRmgi1n1LTZpecfGUuqskzP/go-cid"
+)
+
+// ResolverAPI provides one documented surface for resolving a Path against
+// /ipfs, /ipld, /ipns and dnslink, replacing the ad-hoc
+// ParsePath(..., WithResolve(true)) calls that used to be sprinkled through
+// individual commands.
+type ResolverAPI interface {
+	// Resolve resolves p to a ResolvedPath. If p is already resolved, it
+	// is returned as-is.
+	Resolve(ctx context.Context, p Path) (ResolvedPath, error)
+
+	// ResolveNode resolves p and fetches the node it points at.
+	ResolveNode(ctx context.Context, p Path) (ipld.Node, error)
+
+	// ResolvePath resolves p through its links as far as they go, returning
+	// the CID of the last node reached and the path segments left
+	// unresolved beneath it.
+	ResolvePath(ctx context.Context, p Path) (cid.Cid, []string, error)
+}
@@ -0,0 +1,442 @@
+package coreapi
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	coreiface "github.com/ipfs/go-ipfs/core/coreapi/interface"
+	caopts "github.com/ipfs/go-ipfs/core/coreapi/interface/options"
+	dag "github.com/ipfs/go-ipfs/merkledag"
+	dagutils "github.com/ipfs/go-ipfs/merkledag/dagutils"
+	ft "github.com/ipfs/go-ipfs/unixfs"
+
+	ipld "gx/ipfs/QmR7TcHkR9nxkUorfi8XMTAMLUK7GiP64TWWBzY3aacc1o/go-ipld-format"
+)
+
+// ObjectAPI implements the coreiface.ObjectAPI interface backed by a go-ipfs
+// node.
+type ObjectAPI struct {
+	*CoreAPI
+	*caopts.ApiSettings
+}
+
+// New creates a new object from a template and returns its path. The
+// default template is an empty ProtoNode; pass
+// options.Object.Type("unixfs-dir") to get an empty UnixFS directory
+// instead.
+func (api *ObjectAPI) New(ctx context.Context, opts ...caopts.ObjectNewOption) (coreiface.Path, error) {
+	settings, err := caopts.ObjectNewOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var nd *dag.ProtoNode
+	switch settings.Type {
+	case "empty":
+		nd = new(dag.ProtoNode)
+	case "unixfs-dir":
+		nd = ft.EmptyDirNode()
+	default:
+		return nil, fmt.Errorf("unknown object template %q", settings.Type)
+	}
+
+	if err := api.node.DAG.Add(ctx, nd); err != nil {
+		return nil, err
+	}
+
+	return resolvedPathFromCid(nd.Cid()), nil
+}
+
+// Put imports a (potentially dag-json or dag-cbor encoded) object into the
+// merkledag and returns its path.
+func (api *ObjectAPI) Put(ctx context.Context, r io.Reader) (coreiface.Path, error) {
+	nd, err := dag.DecodeProtobuf(mustReadAll(r))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := api.node.DAG.Add(ctx, nd); err != nil {
+		return nil, err
+	}
+
+	return resolvedPathFromCid(nd.Cid()), nil
+}
+
+// Get resolves the path and returns the underlying ipld.Node.
+func (api *ObjectAPI) Get(ctx context.Context, p coreiface.Path) (ipld.Node, error) {
+	return api.node.DAG.Get(ctx, p.Cid())
+}
+
+// Data returns a reader over the raw data segment of the object.
+func (api *ObjectAPI) Data(ctx context.Context, p coreiface.Path) (io.Reader, error) {
+	nd, err := api.protoNode(ctx, p)
+	if err != nil {
+		return nil, err
+	}
+
+	return bytes.NewReader(nd.Data()), nil
+}
+
+// Links returns the links of the object.
+func (api *ObjectAPI) Links(ctx context.Context, p coreiface.Path) ([]*ipld.Link, error) {
+	nd, err := api.Get(ctx, p)
+	if err != nil {
+		return nil, err
+	}
+
+	return nd.Links(), nil
+}
+
+// Stat returns a statistical summary of the object.
+func (api *ObjectAPI) Stat(ctx context.Context, p coreiface.Path) (*coreiface.ObjectStat, error) {
+	nd, err := api.protoNode(ctx, p)
+	if err != nil {
+		return nil, err
+	}
+
+	enc, err := nd.EncodeProtobuf(false)
+	if err != nil {
+		return nil, err
+	}
+
+	cumulativeSize := len(enc)
+	for _, l := range nd.Links() {
+		cumulativeSize += int(l.Size)
+	}
+
+	return &coreiface.ObjectStat{
+		Cid:            nd.Cid(),
+		NumLinks:       len(nd.Links()),
+		BlockSize:      len(enc),
+		LinksSize:      len(enc) - len(nd.Data()),
+		DataSize:       len(nd.Data()),
+		CumulativeSize: cumulativeSize,
+	}, nil
+}
+
+// AddLink adds a link named name to child under base, returning the path of
+// the resulting object. If opts include WithCreate(true), missing
+// intermediary directories along name are created.
+func (api *ObjectAPI) AddLink(ctx context.Context, base coreiface.Path, name string, child coreiface.Path, opts ...coreiface.ObjectAddLinkOption) (coreiface.Path, error) {
+	settings := new(coreiface.ObjectAddLinkSettings)
+	for _, opt := range opts {
+		opt(settings)
+	}
+
+	baseNd, err := api.protoNode(ctx, base)
+	if err != nil {
+		return nil, err
+	}
+
+	childNd, err := api.Get(ctx, child)
+	if err != nil {
+		return nil, err
+	}
+
+	e := dagutils.NewDagEditor(baseNd, api.node.DAG)
+	if settings.Create {
+		err = e.InsertNodeAtPath(ctx, name, childNd, func() *dag.ProtoNode { return new(dag.ProtoNode) })
+	} else {
+		err = e.InsertNodeAtPath(ctx, name, childNd, nil)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	nnd, err := e.Finalize(ctx, api.node.DAG)
+	if err != nil {
+		return nil, err
+	}
+
+	return resolvedPathFromCid(nnd.Cid()), nil
+}
+
+// RmLink removes the link named link from base, returning the path of the
+// resulting object.
+func (api *ObjectAPI) RmLink(ctx context.Context, base coreiface.Path, link string) (coreiface.Path, error) {
+	baseNd, err := api.protoNode(ctx, base)
+	if err != nil {
+		return nil, err
+	}
+
+	e := dagutils.NewDagEditor(baseNd, api.node.DAG)
+	if err := e.RmLink(ctx, link); err != nil {
+		return nil, err
+	}
+
+	nnd, err := e.Finalize(ctx, api.node.DAG)
+	if err != nil {
+		return nil, err
+	}
+
+	return resolvedPathFromCid(nnd.Cid()), nil
+}
+
+// AppendData appends r to the raw data segment of the object at p.
+func (api *ObjectAPI) AppendData(ctx context.Context, p coreiface.Path, r io.Reader) (coreiface.Path, error) {
+	nd, err := api.protoNode(ctx, p)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := nd.SetData(append(nd.Data(), data...)); err != nil {
+		return nil, err
+	}
+
+	return api.addAndPin(ctx, nd)
+}
+
+// SetData replaces the raw data segment of the object at p with r.
+func (api *ObjectAPI) SetData(ctx context.Context, p coreiface.Path, r io.Reader) (coreiface.Path, error) {
+	nd, err := api.protoNode(ctx, p)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := nd.SetData(data); err != nil {
+		return nil, err
+	}
+
+	return api.addAndPin(ctx, nd)
+}
+
+// WithCreate toggles whether AddLink creates missing intermediary nodes
+// along the link path.
+func (api *ObjectAPI) WithCreate(create bool) coreiface.ObjectAddLinkOption {
+	return func(settings *coreiface.ObjectAddLinkSettings) {
+		settings.Create = create
+	}
+}
+
+// Diff computes the set of link-level changes needed to transform before
+// into after. Links are matched by name: a name present on only one side
+// yields an Add/Remove change, a name present on both sides with differing
+// CIDs yields a Mod change.
+func (api *ObjectAPI) Diff(ctx context.Context, before coreiface.Path, after coreiface.Path) ([]coreiface.ObjectChange, error) {
+	beforeNd, err := api.Get(ctx, before)
+	if err != nil {
+		return nil, err
+	}
+
+	afterNd, err := api.Get(ctx, after)
+	if err != nil {
+		return nil, err
+	}
+
+	changes, err := dagutils.Diff(ctx, api.node.DAG, beforeNd, afterNd)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]coreiface.ObjectChange, 0, len(changes))
+	for _, c := range changes {
+		oc := coreiface.ObjectChange{Path: c.Path}
+
+		switch c.Type {
+		case dagutils.Add:
+			oc.Type = coreiface.DiffAdd
+		case dagutils.Remove:
+			oc.Type = coreiface.DiffRemove
+		case dagutils.Mod:
+			oc.Type = coreiface.DiffMod
+		}
+
+		if c.Before != nil {
+			oc.Before = resolvedPathFromCid(*c.Before)
+		}
+		if c.After != nil {
+			oc.After = resolvedPathFromCid(*c.After)
+		}
+
+		out = append(out, oc)
+	}
+
+	return out, nil
+}
+
+// Batch returns an ObjectBatch seeded with the node at root, letting callers
+// queue up AppendData/SetData/AddLink/RmLink operations against it in
+// memory before writing the result with a single Commit call.
+func (api *ObjectAPI) Batch(ctx context.Context, root coreiface.Path) (coreiface.ObjectBatch, error) {
+	nd, err := api.protoNode(ctx, root)
+	if err != nil {
+		return nil, err
+	}
+
+	return &objectBatch{ctx: ctx, api: api, editor: dagutils.NewDagEditor(nd, api.node.DAG)}, nil
+}
+
+// objectBatch is the coreapi implementation of coreiface.ObjectBatch. Every
+// op mutates the same editor in place, so nodes one op creates or modifies
+// (e.g. the intermediary directories AddLink's create:true walks through)
+// stay visible to later ops and to Commit, which is the only call that
+// writes to the blockstore.
+type objectBatch struct {
+	ctx    context.Context
+	api    *ObjectAPI
+	editor *dagutils.Editor
+}
+
+func (b *objectBatch) AppendData(r io.Reader) error {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return err
+	}
+
+	nd := b.editor.GetNode()
+	return nd.SetData(append(nd.Data(), data...))
+}
+
+func (b *objectBatch) SetData(r io.Reader) error {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return err
+	}
+
+	return b.editor.GetNode().SetData(data)
+}
+
+func (b *objectBatch) AddLink(name string, child coreiface.Path, opts ...coreiface.ObjectAddLinkOption) error {
+	settings := new(coreiface.ObjectAddLinkSettings)
+	for _, opt := range opts {
+		opt(settings)
+	}
+
+	childNd, err := b.api.Get(b.ctx, child)
+	if err != nil {
+		return err
+	}
+
+	if settings.Create {
+		return b.editor.InsertNodeAtPath(b.ctx, name, childNd, func() *dag.ProtoNode { return new(dag.ProtoNode) })
+	}
+	return b.editor.InsertNodeAtPath(b.ctx, name, childNd, nil)
+}
+
+func (b *objectBatch) RmLink(name string) error {
+	return b.editor.RmLink(b.ctx, name)
+}
+
+// Commit writes the accumulated node tree to the blockstore in one shot and
+// returns the path of the resulting root, instead of the store-per-op
+// pattern used by the individual patch commands.
+func (b *objectBatch) Commit() (coreiface.Path, error) {
+	nnd, err := b.editor.Finalize(b.ctx, b.api.node.DAG)
+	if err != nil {
+		return nil, err
+	}
+
+	return resolvedPathFromCid(nnd.Cid()), nil
+}
+
+// Apply consumes ops in order against root, applying each to the same
+// in-memory editor and reporting the resulting CID on results after every
+// op, without writing to the blockstore until ops is closed - at which point
+// the accumulated node is committed once and a final, Root-flagged result
+// is sent.
+func (api *ObjectAPI) Apply(ctx context.Context, root coreiface.Path, ops <-chan coreiface.ObjectOp) (<-chan coreiface.ObjectResult, error) {
+	nd, err := api.protoNode(ctx, root)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make(chan coreiface.ObjectResult)
+
+	go func() {
+		defer close(results)
+
+		e := dagutils.NewDagEditor(nd, api.node.DAG)
+
+		for op := range ops {
+			if op.Err != nil {
+				results <- coreiface.ObjectResult{Err: op.Err}
+				return
+			}
+
+			if err := applyOp(ctx, api, e, op); err != nil {
+				results <- coreiface.ObjectResult{Err: err}
+				return
+			}
+
+			results <- coreiface.ObjectResult{Cid: e.GetNode().Cid()}
+		}
+
+		nnd, err := e.Finalize(ctx, api.node.DAG)
+		if err != nil {
+			results <- coreiface.ObjectResult{Err: err}
+			return
+		}
+
+		results <- coreiface.ObjectResult{Cid: nnd.Cid(), Root: true}
+	}()
+
+	return results, nil
+}
+
+func applyOp(ctx context.Context, api *ObjectAPI, e *dagutils.Editor, op coreiface.ObjectOp) error {
+	switch op.Op {
+	case coreiface.OpAddLink:
+		childNd, err := api.Get(ctx, op.Ref)
+		if err != nil {
+			return err
+		}
+
+		if op.Create {
+			return e.InsertNodeAtPath(ctx, op.Name, childNd, func() *dag.ProtoNode { return new(dag.ProtoNode) })
+		}
+		return e.InsertNodeAtPath(ctx, op.Name, childNd, nil)
+
+	case coreiface.OpRmLink:
+		return e.RmLink(ctx, op.Name)
+
+	case coreiface.OpAppendData:
+		cur := e.GetNode()
+		return cur.SetData(append(cur.Data(), op.Data...))
+
+	case coreiface.OpSetData:
+		return e.GetNode().SetData(op.Data)
+
+	default:
+		return fmt.Errorf("unknown op %q", op.Op)
+	}
+}
+
+func (api *ObjectAPI) protoNode(ctx context.Context, p coreiface.Path) (*dag.ProtoNode, error) {
+	nd, err := api.Get(ctx, p)
+	if err != nil {
+		return nil, err
+	}
+
+	pbnd, ok := nd.(*dag.ProtoNode)
+	if !ok {
+		return nil, dag.ErrNotProtobuf
+	}
+
+	return pbnd.Copy().(*dag.ProtoNode), nil
+}
+
+func (api *ObjectAPI) addAndPin(ctx context.Context, nd *dag.ProtoNode) (coreiface.Path, error) {
+	if err := api.node.DAG.Add(ctx, nd); err != nil {
+		return nil, err
+	}
+
+	return resolvedPathFromCid(nd.Cid()), nil
+}
+
+func mustReadAll(r io.Reader) []byte {
+	b, _ := ioutil.ReadAll(r)
+	return b
+}
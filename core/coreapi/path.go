@@ -0,0 +1,87 @@
+package coreapi
+
+import (
+	"context"
+	"strings"
+
+	coreiface "github.com/ipfs/go-ipfs/core/coreapi/interface"
+	caopts "github.com/ipfs/go-ipfs/core/coreapi/interface/options"
+	path "github.com/ipfs/go-ipfs/path"
+
+	cid "gx/ipfs/QmcTcsTvfaeEBRFo1TkFgT8sRmgi1n1LTZpecfGUuqskzP/go-cid"
+)
+
+// resolvedPath is the concrete implementation of coreiface.Path/ResolvedPath
+// backed by a root CID and an optional remainder.
+type resolvedPath struct {
+	root      cid.Cid
+	remainder string
+}
+
+func newResolvedPath(root cid.Cid, remainder string) coreiface.ResolvedPath {
+	return resolvedPath{root: root, remainder: remainder}
+}
+
+// resolvedPathFromCid wraps a bare CID (no remainder) into a coreiface.Path,
+// the common case when an operation produces a brand new root object.
+func resolvedPathFromCid(c cid.Cid) coreiface.Path {
+	return newResolvedPath(c, "")
+}
+
+func (p resolvedPath) String() string {
+	if p.remainder == "" {
+		return "/ipfs/" + p.root.String()
+	}
+	return "/ipfs/" + p.root.String() + "/" + p.remainder
+}
+
+func (p resolvedPath) Cid() cid.Cid { return p.root }
+
+func (p resolvedPath) Root() cid.Cid { return p.root }
+
+func (p resolvedPath) Remainder() string { return p.remainder }
+
+// ParsePath parses and, unless WithResolve(false) is passed, resolves the
+// given path string against /ipfs, /ipld or /ipns.
+func (api *CoreAPI) ParsePath(ctx context.Context, p string, opts ...caopts.ApiOption) (coreiface.Path, error) {
+	settings, err := caopts.ApiOptions(opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if !settings.Resolve {
+		return unresolvedPath(p), nil
+	}
+
+	fpath := path.Path(p)
+	if err := fpath.IsValid(); err != nil {
+		return nil, err
+	}
+
+	c, remainder, err := api.node.Resolver.ResolveToLastNode(ctx, fpath)
+	if err != nil {
+		return nil, err
+	}
+
+	return newResolvedPath(c, strings.Join(remainder, "/")), nil
+}
+
+// WithResolve toggles path resolution for ParsePath.
+func (api *CoreAPI) WithResolve(resolve bool) caopts.ApiOption {
+	return func(settings *caopts.ApiSettings) error {
+		settings.Resolve = resolve
+		return nil
+	}
+}
+
+// unresolvedPath is used when the caller explicitly opts out of resolution
+// via WithResolve(false); it only knows how to render itself back out.
+type unresolvedPath string
+
+func (p unresolvedPath) String() string { return string(p) }
+
+func (p unresolvedPath) Cid() cid.Cid { return cid.Undef }
+
+func (p unresolvedPath) Root() cid.Cid { return cid.Undef }
+
+func (p unresolvedPath) Remainder() string { return "" }
@@ -0,0 +1,102 @@
+package coreapi
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	coreiface "github.com/ipfs/go-ipfs/core/coreapi/interface"
+	path "github.com/ipfs/go-ipfs/path"
+
+	ipld "gx/ipfs/QmR7TcHkR9nxkUorfi8XMTAMLUK7GiP64TWWBzY3aacc1o/go-ipld-format"
+	cid "gx/ipfs/QmcTcsTvfaeEBRFo1TkFgT8sRmgi1n1LTZpecfGUuqskzP/go-cid"
+)
+
+// resolvedEntry is a single cached ResolvePath result: the root CID and the
+// remainder segments beneath it, kept together so a cache hit can't return
+// a stale or missing remainder for a path that has one.
+type resolvedEntry struct {
+	cid       cid.Cid
+	remainder []string
+}
+
+// ResolverAPI is the coreapi implementation of coreiface.ResolverAPI. It
+// caches the resolution of every path string it resolves, so that repeat
+// lookups of the same /ipns or dnslink path within a request don't each pay
+// for a fresh IPNS/DNS round trip.
+type ResolverAPI struct {
+	*CoreAPI
+
+	mu    sync.Mutex
+	cache map[string]resolvedEntry
+}
+
+func newResolverAPI(api *CoreAPI) *ResolverAPI {
+	return &ResolverAPI{CoreAPI: api, cache: map[string]resolvedEntry{}}
+}
+
+// ResolvePath resolves p through its links as far as they go, returning the
+// CID of the last node reached and the path segments left unresolved beneath
+// it, handling /ipfs, /ipld, /ipns and dnslink uniformly.
+func (api *ResolverAPI) ResolvePath(ctx context.Context, p coreiface.Path) (cid.Cid, []string, error) {
+	if rp, ok := p.(resolvedPath); ok {
+		return rp.Root(), splitRemainder(rp.Remainder()), nil
+	}
+
+	str := p.String()
+
+	api.mu.Lock()
+	if entry, ok := api.cache[str]; ok {
+		api.mu.Unlock()
+		return entry.cid, entry.remainder, nil
+	}
+	api.mu.Unlock()
+
+	fpath := path.Path(str)
+	if err := fpath.IsValid(); err != nil {
+		return cid.Undef, nil, err
+	}
+
+	c, remainder, err := api.node.Resolver.ResolveToLastNode(ctx, fpath)
+	if err != nil {
+		return cid.Undef, nil, err
+	}
+
+	api.mu.Lock()
+	api.cache[str] = resolvedEntry{cid: c, remainder: remainder}
+	api.mu.Unlock()
+
+	return c, remainder, nil
+}
+
+// Resolve resolves p to a ResolvedPath, passing already-resolved paths
+// through untouched.
+func (api *ResolverAPI) Resolve(ctx context.Context, p coreiface.Path) (coreiface.ResolvedPath, error) {
+	if rp, ok := p.(resolvedPath); ok {
+		return rp, nil
+	}
+
+	c, remainder, err := api.ResolvePath(ctx, p)
+	if err != nil {
+		return nil, err
+	}
+
+	return newResolvedPath(c, strings.Join(remainder, "/")), nil
+}
+
+// ResolveNode resolves p and fetches the node it points at.
+func (api *ResolverAPI) ResolveNode(ctx context.Context, p coreiface.Path) (ipld.Node, error) {
+	rp, err := api.Resolve(ctx, p)
+	if err != nil {
+		return nil, err
+	}
+
+	return api.node.DAG.Get(ctx, rp.Cid())
+}
+
+func splitRemainder(r string) []string {
+	if r == "" {
+		return nil
+	}
+	return strings.Split(r, "/")
+}